@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CopyFromContainer copies a path out of a container's filesystem to the local filesystem,
+// mirroring `docker cp CONTAINER:PATH LOCALPATH`.
+func CopyFromContainer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	containerID, ok := request.Params.Arguments["container_id"].(string)
+	if !ok || containerID == "" {
+		return mcp.NewToolResultText("container_id is required"), nil
+	}
+
+	containerSrcPath, ok := request.Params.Arguments["container_src_path"].(string)
+	if !ok || containerSrcPath == "" {
+		return mcp.NewToolResultText("container_src_path is required"), nil
+	}
+
+	localDest, ok := request.Params.Arguments["local_dest"].(string)
+	if !ok || localDest == "" {
+		return mcp.NewToolResultText("local_dest is required"), nil
+	}
+
+	cli, err := GetClient()
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error getting Docker client: %v", err)), nil
+	}
+
+	reader, stat, err := cli.CopyFromContainer(ctx, containerID, containerSrcPath)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error copying from container: %v", err)), nil
+	}
+	defer reader.Close()
+
+	// local_dest = "-" writes the tar archive, base64-encoded, to the tool result instead of the filesystem,
+	// matching `docker cp CONTAINER:PATH -`.
+	if localDest == "-" {
+		tarBytes, err := io.ReadAll(reader)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Error reading tar stream: %v", err)), nil
+		}
+		return mcp.NewToolResultText(base64.StdEncoding.EncodeToString(tarBytes)), nil
+	}
+
+	if err := extractTarArchive(reader, localDest, stat.Mode.IsDir()); err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error extracting tar stream: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Successfully copied %s:%s to %s", containerID, containerSrcPath, localDest)), nil
+}
+
+// extractTarArchive extracts a tar stream retrieved from CopyFromContainer into localDest,
+// mirroring `docker cp CONTAINER:PATH LOCALPATH`. For a file source, see extractFileEntry. For
+// a directory source, the archive's entries are prefixed with the source directory's own base
+// name; if localDest already exists as a directory, the source is unpacked as its child (so the
+// entries' existing prefix lands correctly); otherwise the source is renamed to localDest, so
+// the top-level prefix is stripped and localDest itself ends up holding the source's contents.
+func extractTarArchive(r io.Reader, localDest string, srcIsDir bool) error {
+	tr := tar.NewReader(r)
+
+	if !srcIsDir {
+		return extractFileEntry(tr, localDest)
+	}
+
+	info, statErr := os.Stat(localDest)
+	rebase := !(statErr == nil && info.IsDir())
+
+	if err := os.MkdirAll(localDest, 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := header.Name
+		if rebase {
+			name = stripTopLevelDir(name)
+			if name == "" {
+				// The top-level directory entry itself: already represented by localDest.
+				continue
+			}
+		}
+
+		target, err := safeJoin(localDest, name)
+		if err != nil {
+			return err
+		}
+
+		if err := writeTarEntryToDisk(tr, header, target); err != nil {
+			return err
+		}
+	}
+}
+
+// stripTopLevelDir removes a tar entry name's first path component (tar names are always
+// "/"-separated), e.g. "foo/bar.txt" -> "bar.txt" and "foo" -> "".
+func stripTopLevelDir(name string) string {
+	idx := strings.Index(name, "/")
+	if idx < 0 {
+		return ""
+	}
+	return name[idx+1:]
+}
+
+// extractFileEntry writes the tar stream's single entry directly to localDest, or as a child of
+// localDest named after the entry if localDest already exists as a directory. Docker's archive
+// for a file source always contains exactly one entry, so this avoids nesting the file under a
+// directory named after localDest the way the directory-source path does.
+func extractFileEntry(tr *tar.Reader, localDest string) error {
+	header, err := tr.Next()
+	if err != nil {
+		return err
+	}
+
+	target := localDest
+	if info, statErr := os.Stat(localDest); statErr == nil && info.IsDir() {
+		target = filepath.Join(localDest, filepath.Base(header.Name))
+	} else if err := os.MkdirAll(filepath.Dir(localDest), 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	return writeTarEntryToDisk(tr, header, target)
+}
+
+// writeTarEntryToDisk materializes a single tar header (and, for regular files, its content
+// read from tr) at target.
+func writeTarEntryToDisk(tr *tar.Reader, header *tar.Header, target string) error {
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, os.FileMode(header.Mode))
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(f, tr)
+		return err
+	case tar.TypeSymlink:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		return os.Symlink(header.Linkname, target)
+	}
+	return nil
+}
+
+// safeJoin joins destDir with a tar entry name, rejecting names (e.g. containing "../") that
+// would resolve outside destDir once joined and cleaned.
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, name)
+	rel, err := filepath.Rel(destDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("tar entry %q escapes destination directory", name)
+	}
+	return target, nil
+}
+
+// StatContainerPath returns metadata about a path inside a container, matching the
+// HEAD-archive behavior exposed by Docker's `X-Docker-Container-Path-Stat` header.
+func StatContainerPath(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	containerID, ok := request.Params.Arguments["container_id"].(string)
+	if !ok || containerID == "" {
+		return mcp.NewToolResultText("container_id is required"), nil
+	}
+
+	containerPath, ok := request.Params.Arguments["container_path"].(string)
+	if !ok || containerPath == "" {
+		return mcp.NewToolResultText("container_path is required"), nil
+	}
+
+	cli, err := GetClient()
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error getting Docker client: %v", err)), nil
+	}
+
+	stat, err := cli.ContainerStatPath(ctx, containerID, containerPath)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error statting container path: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"name=%s size=%d mode=%s mtime=%s linkTarget=%s",
+		stat.Name, stat.Size, stat.Mode, stat.Mtime.Format("2006-01-02T15:04:05Z07:00"), stat.LinkTarget,
+	)), nil
+}
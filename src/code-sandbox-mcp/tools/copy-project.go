@@ -4,16 +4,21 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/moby/patternmatcher"
 )
 
 // CopyProject copies a local directory to a container's filesystem
@@ -24,13 +29,29 @@ func CopyProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 		return mcp.NewToolResultText("container_id is required"), nil
 	}
 
-	localSrcDir, ok := request.Params.Arguments["local_src_dir"].(string)
-	if !ok || localSrcDir == "" {
+	rawLocalSrcDir, ok := request.Params.Arguments["local_src_dir"].(string)
+	if !ok || rawLocalSrcDir == "" {
 		return mcp.NewToolResultText("local_src_dir is required"), nil
 	}
 
+	cli, err := GetClient()
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error getting Docker client: %v", err)), nil
+	}
+
+	// local_src_dir = "-" reads a pre-built tar archive from src_tar (base64-encoded) instead
+	// of walking a local directory, mirroring `docker cp -` reading a tar from stdin on upload.
+	if rawLocalSrcDir == "-" {
+		return copyRawTarToContainer(ctx, cli, containerID, request)
+	}
+
+	// A trailing "/." (as in `docker cp src/. container:dest`) copies the contents of the
+	// source directory rather than the directory itself; filepath.Clean would strip this
+	// suffix, so it must be checked before cleaning.
+	copySrcContentsOnly := strings.HasSuffix(rawLocalSrcDir, string(filepath.Separator)+".")
+
 	// Clean and validate the source path
-	localSrcDir = filepath.Clean(localSrcDir)
+	localSrcDir := filepath.Clean(rawLocalSrcDir)
 	info, err := os.Stat(localSrcDir)
 	if err != nil {
 		return mcp.NewToolResultText(fmt.Sprintf("Error accessing source directory: %v", err)), nil
@@ -41,42 +62,193 @@ func CopyProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 	}
 
 	// Get the destination path (optional parameter)
-	destDir, ok := request.Params.Arguments["dest_dir"].(string)
-	copyToHomeDir := false
+	rawDestDir, hasDestDir := request.Params.Arguments["dest_dir"].(string)
+
+	var targetPath string
+	var archiveRoot string
 
-	if !ok || destDir == "" || destDir == "." {
-		// Default: copy contents directly to /app directory in the container
-		destDir = "/app"
-		copyToHomeDir = true
+	if !hasDestDir || rawDestDir == "" || rawDestDir == "." {
+		// Default: copy contents directly into the container's WORKDIR (falling back to $HOME,
+		// then /app) rather than always assuming /app, which many images don't use.
+		defaultDestDir, err := defaultContainerDestDir(ctx, cli, containerID)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Error determining default destination: %v", err)), nil
+		}
+		targetPath = defaultDestDir
+		archiveRoot = ""
 	} else {
+		// filepath.Clean strips a trailing "/" -- capture it first so resolveCopyDestination
+		// still sees the original create-as-child intent.
+		hadTrailingSlash := strings.HasSuffix(rawDestDir, "/")
+		destDir := filepath.Clean(rawDestDir)
 		// If provided but doesn't start with /, prepend /app/
 		if !strings.HasPrefix(destDir, "/") {
 			destDir = filepath.Join("/app", destDir)
+			rawDestDir = destDir
+			if hadTrailingSlash {
+				rawDestDir += "/"
+			}
+		}
+
+		targetPath, archiveRoot, err = resolveCopyDestination(ctx, cli, containerID, rawDestDir, destDir, filepath.Base(localSrcDir), copySrcContentsOnly)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Error resolving dest_dir: %v", err)), nil
 		}
 	}
 
-	// Create tar archive of the source directory
-	tarBuffer, err := createTarArchive(localSrcDir, copyToHomeDir)
+	// follow_link resolves symlinks to their targets before archiving (like `docker cp -L`)
+	followLink, _ := request.Params.Arguments["follow_link"].(bool)
+
+	// copy_uid_gid preserves the local file owner/group in the tar headers (like `docker cp -a`)
+	copyUIDGID, _ := request.Params.Arguments["copy_uid_gid"].(bool)
+
+	// ignore_patterns is merged with any .dockerignore found at the root of localSrcDir
+	var ignorePatterns []string
+	if raw, ok := request.Params.Arguments["ignore_patterns"].([]interface{}); ok {
+		for _, p := range raw {
+			if pattern, ok := p.(string); ok {
+				ignorePatterns = append(ignorePatterns, pattern)
+			}
+		}
+	}
+
+	ignorePatterns, err = loadIgnorePatterns(localSrcDir, ignorePatterns)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("Error creating tar archive: %v", err)), nil
+		return mcp.NewToolResultText(fmt.Sprintf("Error loading ignore patterns: %v", err)), nil
 	}
 
-	// CopyToContainer directly extracts the tar to the destination
+	// Stream the tar archive directly into the container instead of buffering it in memory,
+	// so multi-GB project uploads don't require holding the whole archive in RAM.
+	tarStream, progress := createTarArchive(localSrcDir, tarOptions{
+		ArchiveRoot:    archiveRoot,
+		FollowLink:     followLink,
+		CopyUIDGID:     copyUIDGID,
+		IgnorePatterns: ignorePatterns,
+	})
+
+	err = copyToContainer(ctx, containerID, targetPath, newProgressReader(ctx, request, tarStream, progress))
+	// Close the pipe's read side so the writeTarArchive goroutine unblocks if CopyToContainer
+	// stopped reading before the archive was fully drained (e.g. on error); a no-op once the
+	// archive was already fully consumed.
+	tarStream.Close()
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error copying to container: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Successfully copied %s to %s in container %s", localSrcDir, targetPath, containerID)), nil
+}
+
+// copyRawTarToContainer streams a pre-built tar archive, given as base64 in the src_tar
+// argument, directly into the container. It bypasses createTarArchive entirely since the
+// caller has already produced the archive, mirroring `docker cp -` on the upload side.
+func copyRawTarToContainer(ctx context.Context, cli *client.Client, containerID string, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	srcTarB64, ok := request.Params.Arguments["src_tar"].(string)
+	if !ok || srcTarB64 == "" {
+		return mcp.NewToolResultText(`src_tar (base64-encoded tar archive) is required when local_src_dir is "-"`), nil
+	}
+
+	tarBytes, err := base64.StdEncoding.DecodeString(srcTarB64)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error decoding src_tar: %v", err)), nil
+	}
+
+	rawDestDir, hasDestDir := request.Params.Arguments["dest_dir"].(string)
+
 	var targetPath string
-	if copyToHomeDir {
-		// Copy contents directly to destDir (home directory)
-		targetPath = destDir
+	if !hasDestDir || rawDestDir == "" || rawDestDir == "." {
+		defaultDestDir, err := defaultContainerDestDir(ctx, cli, containerID)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Error determining default destination: %v", err)), nil
+		}
+		targetPath = defaultDestDir
 	} else {
-		// We need to copy to the parent of destDir and let it create the final directory
-		targetPath = filepath.Dir(destDir)
+		targetPath = rawDestDir
+		if !strings.HasPrefix(targetPath, "/") {
+			targetPath = filepath.Join("/app", targetPath)
+		}
 	}
 
-	err = copyToContainer(ctx, containerID, targetPath, tarBuffer)
-	if err != nil {
+	if err := copyToContainer(ctx, containerID, targetPath, bytes.NewReader(tarBytes)); err != nil {
 		return mcp.NewToolResultText(fmt.Sprintf("Error copying to container: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Successfully copied %s to %s in container %s", localSrcDir, destDir, containerID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Successfully copied tar archive to %s in container %s", targetPath, containerID)), nil
+}
+
+// defaultContainerDestDir picks where CopyProject should land files when dest_dir isn't given,
+// preferring the container's declared WORKDIR (as most language images set one), then the
+// exec'd user's $HOME, and only then /app.
+func defaultContainerDestDir(ctx context.Context, cli *client.Client, containerID string) (string, error) {
+	inspect, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if inspect.Config != nil && inspect.Config.WorkingDir != "" {
+		return inspect.Config.WorkingDir, nil
+	}
+
+	if homeDir, err := getContainerHomeDir(ctx, cli, containerID); err == nil && homeDir != "" {
+		return homeDir, nil
+	}
+
+	return "/app", nil
+}
+
+// resolveCopyDestination applies `docker cp`'s trailing-slash destination rules (see moby
+// PR #286) to a directory source whose own base name is srcBaseName: an explicit dest_dir
+// ending in "/" forces the source to be created as a child of dest_dir; otherwise, if dest_dir
+// already exists in the container it's treated as that child's parent, and if it doesn't exist
+// the source is renamed to dest_dir. copySrcContentsOnly is set when local_src_dir ends in
+// "/.", which copies the source's contents into an existing dest_dir rather than creating a new
+// directory.
+//
+// Docker's archive-extract endpoint requires its target directory to already exist -- it won't
+// create the leaf -- so whenever dest_dir itself doesn't exist, targetPath is dest_dir's parent
+// and archiveRoot rebases the archive's top-level entries under dest_dir's own base name (or,
+// for the forced-child case, under dest_dir's base name joined with srcBaseName, since dest_dir
+// must still be created as a directory before the source can nest under it). archiveRoot is ""
+// when the archive should be extracted flat, with no enclosing directory, into targetPath.
+func resolveCopyDestination(ctx context.Context, cli *client.Client, containerID, rawDestDir, destDir, srcBaseName string, copySrcContentsOnly bool) (targetPath, archiveRoot string, err error) {
+	if destDir == "/" {
+		// The container root always exists; extract directly into it rather than relying on
+		// a naive filepath.Dir split, which would produce an empty/incorrect parent path.
+		return "/", "", nil
+	}
+
+	stat, statErr := cli.ContainerStatPath(ctx, containerID, destDir)
+	destExists := statErr == nil
+	destIsDir := destExists && stat.Mode&os.ModeDir != 0
+
+	if copySrcContentsOnly {
+		if !destExists {
+			return "", "", fmt.Errorf("dest_dir %q must already exist as a directory: %w", destDir, statErr)
+		}
+		if !destIsDir {
+			return "", "", fmt.Errorf("dest_dir %q must be a directory", destDir)
+		}
+		return destDir, "", nil
+	}
+
+	if destExists {
+		if !destIsDir {
+			return "", "", fmt.Errorf("dest_dir %q exists and is not a directory", destDir)
+		}
+		// dest_dir exists: the source directory is created as a child of dest_dir.
+		return destDir, srcBaseName, nil
+	}
+
+	// dest_dir doesn't exist, so it can't be used as the extraction target. Extract into its
+	// parent instead and rebase the archive's top-level entry to dest_dir's own base name.
+	parent := filepath.Dir(destDir)
+	rebasedName := filepath.Base(destDir)
+	if strings.HasSuffix(rawDestDir, "/") {
+		// A trailing "/" forces the source to be created as a child of dest_dir once dest_dir
+		// itself comes into existence as part of this extraction.
+		return parent, filepath.Join(rebasedName, srcBaseName), nil
+	}
+	// No trailing slash: rename the source to dest_dir.
+	return parent, rebasedName, nil
 }
 
 // getContainerHomeDir gets the home directory of the user running in the container
@@ -126,27 +298,89 @@ func getContainerHomeDir(ctx context.Context, cli *client.Client, containerID st
 	return homeDir, nil
 }
 
-// createTarArchive creates a tar archive of the specified source path
-func createTarArchive(srcPath string, copyContentsOnly bool) (io.Reader, error) {
-	buf := new(bytes.Buffer)
-	tw := tar.NewWriter(buf)
+// tarOptions controls how createTarArchive walks and packages the source tree.
+type tarOptions struct {
+	// ArchiveRoot is joined onto every tar entry's name, so the archive's contents land under a
+	// directory with this name in the destination rather than directly in it. An empty
+	// ArchiveRoot omits the enclosing directory entirely, landing the contents directly in the
+	// destination.
+	ArchiveRoot string
+	// FollowLink resolves symlinks to their targets before archiving, like `docker cp -L`.
+	FollowLink bool
+	// CopyUIDGID preserves the local file owner/group in the tar headers, like `docker cp -a`.
+	// When false, ownership is forced to uid=0/gid=0 so the container's user owns the files.
+	CopyUIDGID bool
+	// IgnorePatterns excludes matching paths from the archive, using Docker's .dockerignore
+	// pattern semantics (leading `!` negation, `**` globs, directory-prefix matching).
+	IgnorePatterns []string
+}
+
+// applyTarOwnership sets the tar header's owner/group fields. When copyUIDGID is true, the
+// local file's owner/group (read from the platform-specific os.FileInfo.Sys()) is preserved;
+// otherwise ownership is forced to uid=0/gid=0 so the container's user owns extracted files.
+func applyTarOwnership(header *tar.Header, fi os.FileInfo, copyUIDGID bool) {
+	if !copyUIDGID {
+		header.Uid = 0
+		header.Gid = 0
+		header.Uname = ""
+		header.Gname = ""
+		return
+	}
+
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	header.Uid = int(stat.Uid)
+	header.Gid = int(stat.Gid)
+
+	if u, err := user.LookupId(strconv.Itoa(header.Uid)); err == nil {
+		header.Uname = u.Username
+	}
+	if g, err := user.LookupGroupId(strconv.Itoa(header.Gid)); err == nil {
+		header.Gname = g.Name
+	}
+}
+
+// createTarArchive streams a tar archive of the specified source path through an io.Pipe,
+// so the caller never needs to hold the whole archive in memory. The returned tarProgress is
+// updated as entries are written and can be read concurrently by a progress reporter. The
+// caller must close the returned *io.PipeReader once it's done reading -- including when it
+// stops early on error -- so the writer goroutine unblocks instead of leaking on a full pipe.
+func createTarArchive(srcPath string, opts tarOptions) (*io.PipeReader, *tarProgress) {
+	pr, pw := io.Pipe()
+	progress := &tarProgress{}
+
+	go func() {
+		pw.CloseWithError(writeTarArchive(pw, srcPath, opts, progress))
+	}()
+
+	return pr, progress
+}
+
+// writeTarArchive walks srcPath and writes a tar stream to w, tracking progress as it goes.
+func writeTarArchive(w io.Writer, srcPath string, opts tarOptions, progress *tarProgress) error {
+	tw := tar.NewWriter(w)
 	defer tw.Close()
 
 	srcPath = filepath.Clean(srcPath)
-	baseDir := filepath.Base(srcPath)
 
-	err := filepath.Walk(srcPath, func(file string, fi os.FileInfo, err error) error {
+	var pm *patternmatcher.PatternMatcher
+	if len(opts.IgnorePatterns) > 0 {
+		var err error
+		pm, err = patternmatcher.New(opts.IgnorePatterns)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid ignore pattern: %w", err)
 		}
+	}
 
-		// Create tar header
-		header, err := tar.FileInfoHeader(fi, fi.Name())
+	return filepath.Walk(srcPath, func(file string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Maintain directory structure relative to the source directory
+		// Maintain directory structure relative to the source directory, based on the
+		// original walked path (not the symlink target it may resolve to below).
 		relPath, err := filepath.Rel(srcPath, file)
 		if err != nil {
 			return err
@@ -157,49 +391,124 @@ func createTarArchive(srcPath string, copyContentsOnly bool) (io.Reader, error)
 			return nil
 		}
 
-		// If copyContentsOnly is true, don't include the base directory name
-		if copyContentsOnly {
-			header.Name = relPath
-		} else {
-			header.Name = filepath.Join(baseDir, relPath)
-		}
+		return writeTarEntry(tw, file, relPath, fi, opts, pm, progress)
+	})
+}
 
-		if err := tw.WriteHeader(header); err != nil {
+// writeTarEntry writes a single tar entry for the file at diskPath to tw, using relPath
+// (relative to the archive's source root) both to check ignore patterns and to compute the tar
+// header name. When diskPath is a symlink, opts.FollowLink is set, and the link resolves to a
+// directory, the resolved directory's full subtree is packaged under relPath instead of a single
+// (empty) directory entry, matching `docker cp -L`'s handling of directory symlinks.
+func writeTarEntry(tw *tar.Writer, diskPath, relPath string, fi os.FileInfo, opts tarOptions, pm *patternmatcher.PatternMatcher, progress *tarProgress) error {
+	if pm != nil {
+		matched, err := pm.Matches(relPath)
+		if err != nil {
 			return err
 		}
+		if matched {
+			if fi.IsDir() {
+				// Only prune the whole subtree when there are no negation ("!") patterns to
+				// re-include a descendant; otherwise keep walking so those exceptions are
+				// still matched against, per Docker's .dockerignore semantics.
+				if !pm.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			return nil
+		}
+	}
 
-		// If it's a regular file, write its content
-		if fi.Mode().IsRegular() {
-			f, err := os.Open(file)
+	readPath := diskPath
+	linkTarget := ""
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if opts.FollowLink {
+			resolved, err := filepath.EvalSymlinks(diskPath)
 			if err != nil {
 				return err
 			}
-			defer f.Close()
-
-			if _, err := io.Copy(tw, f); err != nil {
+			resolvedInfo, err := os.Stat(resolved)
+			if err != nil {
 				return err
 			}
+			if resolvedInfo.IsDir() {
+				return writeTarDirTree(tw, resolved, relPath, opts, pm, progress)
+			}
+			readPath = resolved
+			fi = resolvedInfo
+		} else if target, err := os.Readlink(diskPath); err == nil {
+			linkTarget = target
 		}
-		return nil
-	})
+	}
 
+	// Create tar header
+	header, err := tar.FileInfoHeader(fi, linkTarget)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	// An empty ArchiveRoot omits the enclosing directory name entirely.
+	if opts.ArchiveRoot == "" {
+		header.Name = relPath
+	} else {
+		header.Name = filepath.Join(opts.ArchiveRoot, relPath)
+	}
+
+	applyTarOwnership(header, fi, opts.CopyUIDGID)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	progress.addFile()
+
+	// If it's a regular file, write its content
+	if fi.Mode().IsRegular() {
+		f, err := os.Open(readPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		written, err := io.Copy(tw, f)
+		progress.addBytes(written)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// writeTarDirTree packages the directory at diskDir -- the resolved target of a followed
+// directory symlink -- into the archive under relPath, recursing into its full subtree the same
+// way the top-level walk in writeTarArchive does.
+func writeTarDirTree(tw *tar.Writer, diskDir, relPath string, opts tarOptions, pm *patternmatcher.PatternMatcher, progress *tarProgress) error {
+	return filepath.Walk(diskDir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 
-	return buf, nil
+		rel, err := filepath.Rel(diskDir, file)
+		if err != nil {
+			return err
+		}
+
+		entryRelPath := relPath
+		if rel != "." {
+			entryRelPath = filepath.Join(relPath, rel)
+		}
+
+		return writeTarEntry(tw, file, entryRelPath, fi, opts, pm, progress)
+	})
 }
 
 // copyToContainer copies a tar archive to a container
 func copyToContainer(ctx context.Context, containerID string, destPath string, tarArchive io.Reader) error {
-	cli, err := client.NewClientWithOpts(
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := GetClient()
 	if err != nil {
-		return fmt.Errorf("failed to create Docker client: %w", err)
+		return fmt.Errorf("failed to get Docker client: %w", err)
 	}
-	defer cli.Close()
 
 	// Make sure the container exists and is running
 	_, err = cli.ContainerInspect(ctx, containerID)
@@ -219,14 +528,10 @@ func copyToContainer(ctx context.Context, containerID string, destPath string, t
 
 // executeCommand runs a command in a container and waits for it to complete
 func executeCommand(ctx context.Context, containerID string, cmd []string) error {
-	cli, err := client.NewClientWithOpts(
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := GetClient()
 	if err != nil {
-		return fmt.Errorf("failed to create Docker client: %w", err)
+		return fmt.Errorf("failed to get Docker client: %w", err)
 	}
-	defer cli.Close()
 
 	// Create the exec configuration
 	exec, err := cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/connhelper"
+)
+
+var (
+	dockerClientOnce sync.Once
+	dockerClient     *client.Client
+	dockerClientErr  error
+)
+
+// GetClient returns a Docker client shared across tool calls, initialized once from the
+// environment (DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH, ...) rather than negotiating
+// a fresh connection and API version on every call.
+//
+// DOCKER_HOST values with an ssh:// (or other connhelper-supported) scheme are routed through
+// github.com/docker/docker/pkg/connhelper, the same mechanism nektos/act uses, so this server
+// can drive a remote Docker daemon.
+func GetClient() (*client.Client, error) {
+	dockerClientOnce.Do(func() {
+		opts := []client.Opt{client.WithAPIVersionNegotiation()}
+
+		helper, err := connhelper.GetConnectionHelper(os.Getenv("DOCKER_HOST"))
+		if err != nil {
+			dockerClientErr = fmt.Errorf("failed to resolve DOCKER_HOST connection helper: %w", err)
+			return
+		}
+
+		if helper != nil {
+			opts = append(opts,
+				client.WithHost(helper.Host),
+				client.WithHTTPClient(&http.Client{
+					Transport: &http.Transport{DialContext: helper.Dialer},
+				}),
+			)
+		} else {
+			opts = append(opts, client.FromEnv)
+		}
+
+		dockerClient, dockerClientErr = client.NewClientWithOpts(opts...)
+	})
+
+	return dockerClient, dockerClientErr
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// loadIgnorePatterns combines explicit ignore patterns with any `.dockerignore` found at the
+// root of srcDir. The file's patterns are read first so explicit patterns (e.g. passed via the
+// ignore_patterns parameter) are appended last and can still override them via `!` negation.
+func loadIgnorePatterns(srcDir string, explicit []string) ([]string, error) {
+	f, err := os.Open(filepath.Join(srcDir, ".dockerignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return explicit, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	fromFile, err := ignorefile.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fromFile, explicit...), nil
+}
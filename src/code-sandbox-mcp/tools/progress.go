@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReportInterval caps how often progress notifications are sent to the client,
+// mirroring the cadence of docker CLI's copyProgressPrinter.
+const progressReportInterval = 250 * time.Millisecond
+
+// tarProgress tracks bytes and files written into a tar stream. It's safe to read
+// concurrently with the goroutine writing the archive.
+type tarProgress struct {
+	bytes int64
+	files int64
+}
+
+func (p *tarProgress) addBytes(n int64) {
+	atomic.AddInt64(&p.bytes, n)
+}
+
+func (p *tarProgress) addFile() {
+	atomic.AddInt64(&p.files, 1)
+}
+
+func (p *tarProgress) snapshot() (bytes, files int64) {
+	return atomic.LoadInt64(&p.bytes), atomic.LoadInt64(&p.files)
+}
+
+// newProgressReader wraps r so that, as it's read, periodic "Copying to container - 152 MB"
+// style progress notifications are sent through the MCP protocol. If the request didn't
+// include a progress token, it returns r unwrapped since there's no client to notify.
+func newProgressReader(ctx context.Context, request mcp.CallToolRequest, r io.Reader, progress *tarProgress) io.Reader {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return r
+	}
+	token := request.Params.Meta.ProgressToken
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return r
+	}
+
+	return &progressReader{
+		ctx:      ctx,
+		r:        r,
+		server:   srv,
+		token:    token,
+		progress: progress,
+	}
+}
+
+type progressReader struct {
+	ctx      context.Context
+	r        io.Reader
+	server   *server.MCPServer
+	token    mcp.ProgressToken
+	progress *tarProgress
+	lastSent time.Time
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+
+	if time.Since(p.lastSent) >= progressReportInterval {
+		p.lastSent = time.Now()
+		bytesCopied, filesCopied := p.progress.snapshot()
+		p.server.SendNotificationToClient(p.ctx, "notifications/progress", map[string]any{
+			"progressToken": p.token,
+			"progress":      bytesCopied,
+			"message":       fmt.Sprintf("Copying to container - %d files, %s", filesCopied, formatBytes(bytesCopied)),
+		})
+	}
+
+	return n, err
+}
+
+// formatBytes renders n as a human-readable size, e.g. "152.0 MB", matching the
+// docker CLI's copy progress output.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}